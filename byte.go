@@ -0,0 +1,122 @@
+package shannon
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ByteCode is a Shannon-Fano code point for a single byte symbol.
+type ByteCode struct {
+	Byte byte
+	Prob float64
+	Bits uint32
+	Size int
+}
+
+// ByteTable is a dense, 256-entry lookup table for encoding and
+// decoding arbitrary binary data one byte at a time. It is the
+// byte-oriented counterpart to Table: trading the flexibility of
+// arbitrary rune symbols for the lower overhead of a fixed-size array
+// instead of a map, so it works on data that isn't valid UTF-8. A zero
+// Size means the byte did not occur in the table's source frequencies.
+//
+// Writer and Reader stream against a rune-keyed Table, not a ByteTable;
+// wiring the two together would mean threading an arbitrary symbol type
+// through the frame format, which is out of scope here.
+type ByteTable [256]ByteCode
+
+// BuildByteTable returns a Shannon-Fano table for encoding and decoding
+// bytes, given the occurrence count of each of the 256 byte values.
+func BuildByteTable(freq [256]uint64) (table ByteTable) {
+	total := uint64(0)
+	for _, n := range freq {
+		total += n
+	}
+
+	if total == 0 {
+		return
+	}
+
+	// initialize the list of codes for every byte that occurred
+	codes := make([]ByteCode, 0, 256)
+
+	for i, n := range freq {
+		if n == 0 {
+			continue
+		}
+
+		codes = append(codes, ByteCode{
+			Byte: byte(i),
+			Prob: float64(n) / float64(total),
+		})
+	}
+
+	// sort the codes by probability
+	sort.Slice(codes, func(a, b int) bool {
+		return codes[a].Prob > codes[b].Prob
+	})
+
+	// assign bits/sizes via the shared Shannon-Fano partitioning
+	probs := make([]float64, len(codes))
+	for i, code := range codes {
+		probs[i] = code.Prob
+	}
+
+	bits := make([]uint32, len(codes))
+	sizes := make([]int, len(codes))
+
+	shannonFanoBuild(probs, bits, sizes)
+
+	// construct the table from all the built codes
+	for i, code := range codes {
+		code.Bits, code.Size = bits[i], sizes[i]
+		table[code.Byte] = code
+	}
+
+	return
+}
+
+// BuildByteTableFromBytes builds a Shannon-Fano ByteTable from the byte
+// occurrence frequencies found in data.
+func BuildByteTableFromBytes(data []byte) ByteTable {
+	var freq [256]uint64
+
+	for _, b := range data {
+		freq[b]++
+	}
+
+	return BuildByteTable(freq)
+}
+
+// Encode a byte slice using a Shannon-Fano ByteTable.
+func (t ByteTable) Encode(data []byte) ([]uint32, int, error) {
+	// add the first set of bits
+	bitVec := make([]uint32, 1)
+	size := 0
+
+	// encode each byte in the slice
+	for _, x := range data {
+		code := t[x]
+		if code.Size == 0 {
+			return nil, 0, fmt.Errorf("byte 0x%02x not found in shannon-fano table", x)
+		}
+
+		// pack if it fits completely
+		if n := size & 0x1F; n+code.Size < 0x20 {
+			bitVec[len(bitVec)-1] |= code.Bits << uint(0x20-n-code.Size)
+		} else {
+			n = code.Size - (0x20 - n)
+
+			// append the last few bits
+			bitVec[len(bitVec)-1] |= code.Bits >> uint(n)
+
+			// create a new entry with the remaining bits
+			bitVec = append(bitVec, code.Bits<<uint(0x20-n))
+		}
+
+		// tally the total size
+		size += code.Size
+	}
+
+	return bitVec, size, nil
+}
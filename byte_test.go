@@ -0,0 +1,83 @@
+package shannon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestByteTableRoundTripSingleByte(t *testing.T) {
+	data := bytes.Repeat([]byte{0x41}, 4)
+
+	table := BuildByteTableFromBytes(data)
+
+	bitVec, size, err := table.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded, data) {
+		t.Fatalf("got %v, want %v", decoded, data)
+	}
+}
+
+func TestByteTableRoundTripTwoBytes(t *testing.T) {
+	data := []byte{0x00, 0xff, 0x00, 0xff, 0x00, 0x00, 0xff}
+
+	table := BuildByteTableFromBytes(data)
+
+	bitVec, size, err := table.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded, data) {
+		t.Fatalf("got %v, want %v", decoded, data)
+	}
+}
+
+func TestByteTableRoundTripMixedDistribution(t *testing.T) {
+	data := make([]byte, 0, 256*3)
+	for i := 0; i < 256; i++ {
+		n := 1
+		if i%7 == 0 {
+			n = 5
+		}
+		for j := 0; j < n; j++ {
+			data = append(data, byte(i))
+		}
+	}
+
+	table := BuildByteTableFromBytes(data)
+
+	bitVec, size, err := table.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(decoded, data) {
+		t.Fatalf("decoded data does not match original (len %d vs %d)", len(decoded), len(data))
+	}
+}
+
+func TestByteTableEncodeUnknownByte(t *testing.T) {
+	table := BuildByteTableFromBytes([]byte{0x01, 0x02})
+
+	if _, _, err := table.Encode([]byte{0xff}); err == nil {
+		t.Fatal("expected an error encoding a byte absent from the table")
+	}
+}
@@ -0,0 +1,153 @@
+package shannon
+
+import "errors"
+
+// byteDecoderNode is a node in the binary prefix-trie built from a
+// ByteTable's codes, the byte-oriented counterpart to decoderNode.
+type byteDecoderNode struct {
+	children [2]*byteDecoderNode
+	isLeaf   bool
+	b        byte
+}
+
+// byteFastEntry mirrors fastEntry for a ByteDecoder's 8-bit lookup
+// table; a zero bits value means the window didn't resolve to a
+// complete code within 8 bits.
+type byteFastEntry struct {
+	b    byte
+	bits int
+}
+
+// ByteDecoder decodes bit streams produced by a ByteTable in O(bits
+// consumed) time by walking a prefix-trie, the byte-oriented
+// counterpart to Decoder. Build one with ByteTable.NewDecoder and reuse
+// it across calls to Decode to amortize the cost of constructing the
+// trie.
+type ByteDecoder struct {
+	root *byteDecoderNode
+	fast [256]byteFastEntry
+}
+
+// NewDecoder builds a ByteDecoder from t, constructing a binary
+// prefix-trie from each ByteCode's Bits/Size and an 8-bit lookup table
+// for the common case where a code is resolved within the next 8 bits.
+func (t ByteTable) NewDecoder() *ByteDecoder {
+	d := &ByteDecoder{root: &byteDecoderNode{}}
+
+	for i, code := range t {
+		if code.Size == 0 {
+			continue
+		}
+
+		node := d.root
+
+		for b := code.Size - 1; b >= 0; b-- {
+			bit := (code.Bits >> uint(b)) & 1
+
+			if node.children[bit] == nil {
+				node.children[bit] = &byteDecoderNode{}
+			}
+
+			node = node.children[bit]
+		}
+
+		node.isLeaf, node.b = true, byte(i)
+	}
+
+	d.buildFastTable()
+
+	return d
+}
+
+// buildFastTable walks the trie for every possible 8-bit window and
+// records the byte/bits-consumed pair for windows that resolve to a
+// complete code within those 8 bits.
+func (d *ByteDecoder) buildFastTable() {
+	for v := 0; v < 256; v++ {
+		node := d.root
+
+		for i := 7; i >= 0; i-- {
+			bit := (v >> uint(i)) & 1
+
+			node = node.children[bit]
+			if node == nil {
+				break
+			}
+
+			if node.isLeaf {
+				d.fast[v] = byteFastEntry{b: node.b, bits: 8 - i}
+				break
+			}
+		}
+	}
+}
+
+// Decode decodes a bit vector produced by ByteTable.Encode back into a
+// byte slice, using the fast table for codes that resolve within 8 bits
+// and falling back to a bit-at-a-time trie walk otherwise.
+func (d *ByteDecoder) Decode(bitVec []uint32, size int) ([]byte, error) {
+	var out []byte
+
+	if len(bitVec) <= size/32 {
+		return nil, errors.New("invalid bit vector")
+	}
+
+	src := &bitSource{words: bitVec}
+
+	for remaining := size; remaining > 0; {
+		n := uint(8)
+		if uint(remaining) < n {
+			n = uint(remaining)
+		}
+
+		src.ensure(n)
+
+		if n == 8 {
+			if fe := d.fast[src.peek(8)]; fe.bits > 0 {
+				out = append(out, fe.b)
+				src.advance(uint(fe.bits))
+				remaining -= fe.bits
+				continue
+			}
+		}
+
+		node, consumed := d.root, 0
+
+		for {
+			src.ensure(1)
+
+			if consumed >= remaining {
+				return out, errors.New("encoded bits remaining")
+			}
+
+			bit := src.peek(1)
+			src.advance(1)
+			consumed++
+
+			if node = node.children[bit]; node == nil {
+				return out, errors.New("invalid bit vector or missing shannon code")
+			}
+
+			if node.isLeaf {
+				out = append(out, node.b)
+				break
+			}
+
+			if consumed > 32 {
+				return out, errors.New("invalid bit vector or missing shannon code")
+			}
+		}
+
+		remaining -= consumed
+	}
+
+	return out, nil
+}
+
+// Decode decodes a bit vector into a byte slice using a Shannon-Fano
+// ByteTable. It builds a ByteDecoder internally; callers decoding
+// repeatedly with the same table should build one with NewDecoder and
+// reuse it instead.
+func (t ByteTable) Decode(bitVec []uint32, size int) ([]byte, error) {
+	return t.NewDecoder().Decode(bitVec, size)
+}
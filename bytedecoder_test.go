@@ -0,0 +1,51 @@
+package shannon
+
+import "testing"
+
+// byteCorpus is a modest binary sample used to benchmark decode
+// throughput across the full byte alphabet.
+var byteCorpus = func() []byte {
+	data := make([]byte, 0, 256*50)
+	for i := 0; i < 50; i++ {
+		for b := 0; b < 256; b++ {
+			data = append(data, byte(b))
+		}
+	}
+	return data
+}()
+
+func BenchmarkByteDecode(b *testing.B) {
+	table := BuildByteTableFromBytes(byteCorpus)
+
+	bitVec, size, err := table.Encode(byteCorpus)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := table.Decode(bitVec, size); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkByteDecoderReuse(b *testing.B) {
+	table := BuildByteTableFromBytes(byteCorpus)
+
+	bitVec, size, err := table.Encode(byteCorpus)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dec := table.NewDecoder()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := dec.Decode(bitVec, size); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
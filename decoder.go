@@ -0,0 +1,193 @@
+package shannon
+
+import (
+	"bytes"
+	"errors"
+)
+
+// decoderNode is a node in the binary prefix-trie built from a Table's
+// codes. Bit 0 follows the left child, bit 1 follows the right child;
+// leaves carry the decoded rune.
+type decoderNode struct {
+	children [2]*decoderNode
+	isLeaf   bool
+	char     rune
+}
+
+// fastEntry is a precomputed result for an 8-bit window of the bit
+// stream: the rune it decodes to and how many of those bits the code
+// actually consumed. A zero bits value means the window didn't resolve
+// to a complete code and the trie must be walked bit-by-bit instead.
+type fastEntry struct {
+	char rune
+	bits int
+}
+
+// Decoder decodes bit streams produced by a Table in O(bits consumed)
+// time by walking a prefix-trie, instead of the O(bits consumed *
+// len(table)) linear scan Table.Decode performed before this type
+// existed. Build one with Table.NewDecoder and reuse it across calls to
+// Decode to amortize the cost of constructing the trie.
+type Decoder struct {
+	root *decoderNode
+	fast [256]fastEntry
+}
+
+// NewDecoder builds a Decoder from t, constructing a binary prefix-trie
+// from each Code's Bits/Size and an 8-bit lookup table for the common
+// case where a code is resolved within the next 8 bits.
+func (t Table) NewDecoder() *Decoder {
+	d := &Decoder{root: &decoderNode{}}
+
+	for r, code := range t {
+		node := d.root
+
+		for i := code.Size - 1; i >= 0; i-- {
+			bit := (code.Bits >> uint(i)) & 1
+
+			if node.children[bit] == nil {
+				node.children[bit] = &decoderNode{}
+			}
+
+			node = node.children[bit]
+		}
+
+		node.isLeaf, node.char = true, r
+	}
+
+	d.buildFastTable()
+
+	return d
+}
+
+// buildFastTable walks the trie for every possible 8-bit window and
+// records the rune/bits-consumed pair for windows that resolve to a
+// complete code within those 8 bits.
+func (d *Decoder) buildFastTable() {
+	for v := 0; v < 256; v++ {
+		node := d.root
+
+		for i := 7; i >= 0; i-- {
+			bit := (v >> uint(i)) & 1
+
+			node = node.children[bit]
+			if node == nil {
+				break
+			}
+
+			if node.isLeaf {
+				d.fast[v] = fastEntry{char: node.char, bits: 8 - i}
+				break
+			}
+		}
+	}
+}
+
+// bitSource is a rolling window over a []uint32 bit vector that lets
+// Decoder peek several bits at a time without popping words off the
+// slice one bit at a time.
+type bitSource struct {
+	words  []uint32
+	window uint64
+	nbits  uint
+}
+
+// ensure refills the window, a word at a time, until at least n bits
+// are available or the underlying words are exhausted.
+func (s *bitSource) ensure(n uint) {
+	for s.nbits < n && len(s.words) > 0 {
+		w := uint64(s.words[0])
+		s.words = s.words[1:]
+		s.window |= w << (32 - s.nbits)
+		s.nbits += 32
+	}
+}
+
+// peek returns the top n bits of the window without consuming them.
+func (s *bitSource) peek(n uint) uint32 {
+	if n == 0 {
+		return 0
+	}
+
+	return uint32(s.window >> (64 - n))
+}
+
+// advance consumes the top n bits of the window.
+func (s *bitSource) advance(n uint) {
+	s.window <<= n
+
+	if n > s.nbits {
+		s.nbits = 0
+	} else {
+		s.nbits -= n
+	}
+}
+
+// Decode decodes a bit vector produced by Table.Encode back into a
+// string, using the fast table for codes that resolve within 8 bits and
+// falling back to a bit-at-a-time trie walk otherwise.
+func (d *Decoder) Decode(bitVec []uint32, size int) (string, error) {
+	var b bytes.Buffer
+
+	if len(bitVec) <= size/32 {
+		return "", errors.New("invalid bit vector")
+	}
+
+	src := &bitSource{words: bitVec}
+
+	for remaining := size; remaining > 0; {
+		n := uint(8)
+		if uint(remaining) < n {
+			n = uint(remaining)
+		}
+
+		src.ensure(n)
+
+		if n == 8 {
+			if fe := d.fast[src.peek(8)]; fe.bits > 0 {
+				b.WriteRune(fe.char)
+				src.advance(uint(fe.bits))
+				remaining -= fe.bits
+				continue
+			}
+		}
+
+		node, consumed := d.root, 0
+
+		for {
+			src.ensure(1)
+
+			if consumed >= remaining {
+				return b.String(), errors.New("encoded bits remaining")
+			}
+
+			bit := src.peek(1)
+			src.advance(1)
+			consumed++
+
+			if node = node.children[bit]; node == nil {
+				return b.String(), errors.New("invalid bit vector or missing shannon code")
+			}
+
+			if node.isLeaf {
+				b.WriteRune(node.char)
+				break
+			}
+
+			if consumed > 32 {
+				return b.String(), errors.New("invalid bit vector or missing shannon code")
+			}
+		}
+
+		remaining -= consumed
+	}
+
+	return b.String(), nil
+}
+
+// Decode decodes a bit vector into a string using a Shannon-Fano table.
+// It builds a Decoder internally; callers decoding repeatedly with the
+// same table should build one with NewDecoder and reuse it instead.
+func (t Table) Decode(bitVec []uint32, size int) (string, error) {
+	return t.NewDecoder().Decode(bitVec, size)
+}
@@ -0,0 +1,64 @@
+package shannon
+
+import (
+	"strings"
+	"testing"
+)
+
+// corpus is a modest Unicode sample used to benchmark decode throughput
+// across a large-ish alphabet.
+var corpus = strings.Repeat("The quick brown fox jumps over the lazy dog. Σὲ γνωρίζω ἀπὸ τὴν κόψη. 北京市", 200)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	table := BuildTableFromString(corpus)
+
+	bitVec, size, err := table.Encode(corpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != corpus {
+		t.Fatalf("decoded text does not match corpus (len %d vs %d)", len(decoded), len(corpus))
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	table := BuildTableFromString(corpus)
+
+	bitVec, size, err := table.Encode(corpus)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := table.Decode(bitVec, size); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoderReuse(b *testing.B) {
+	table := BuildTableFromString(corpus)
+
+	bitVec, size, err := table.Encode(corpus)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	dec := table.NewDecoder()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := dec.Decode(bitVec, size); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
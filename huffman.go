@@ -0,0 +1,93 @@
+package shannon
+
+import "container/heap"
+
+// huffmanNode is an internal or leaf node in the Huffman tree built by
+// BuildHuffmanTable.
+type huffmanNode struct {
+	char        rune
+	prob        float64
+	leaf        bool
+	left, right *huffmanNode
+}
+
+// huffmanHeap is a min-heap of huffmanNode ordered by probability, used
+// to repeatedly merge the two lowest-probability nodes.
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int           { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool { return h[i].prob < h[j].prob }
+func (h huffmanHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *huffmanHeap) Push(x interface{}) {
+	*h = append(*h, x.(*huffmanNode))
+}
+
+func (h *huffmanHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	node := old[n-1]
+	*h = old[:n-1]
+	return node
+}
+
+// BuildHuffmanTable returns a prefix table for freq built with Huffman's
+// algorithm instead of the Shannon-Fano partitioning BuildTable uses.
+// Huffman's algorithm is optimal: no prefix code achieves a lower
+// expected bits/symbol for the given distribution, whereas Shannon-Fano
+// can produce strictly longer codes. The resulting Table has the same
+// Code shape as BuildTable's and is a drop-in replacement anywhere a
+// Table is used.
+func BuildHuffmanTable(freq map[rune]float64) Table {
+	h := make(huffmanHeap, 0, len(freq))
+
+	for r, prob := range freq {
+		h = append(h, &huffmanNode{char: r, prob: prob, leaf: true})
+	}
+
+	heap.Init(&h)
+
+	// repeatedly merge the two lowest-probability nodes until one remains
+	for h.Len() > 1 {
+		a := heap.Pop(&h).(*huffmanNode)
+		b := heap.Pop(&h).(*huffmanNode)
+
+		heap.Push(&h, &huffmanNode{
+			prob:  a.prob + b.prob,
+			left:  a,
+			right: b,
+		})
+	}
+
+	table := make(Table, len(freq))
+
+	if h.Len() == 0 {
+		return table
+	}
+
+	// a single-symbol alphabet never merges, so the root is already a
+	// leaf; give it an explicit 1-bit code rather than the 0-bit code
+	// the DFS below would otherwise leave it with, the same fix applied
+	// to shannonFanoBuild for BuildTable/BuildByteTable.
+	if root := h[0]; root.leaf {
+		table[root.char] = Code{Char: root.char, Prob: root.prob, Bits: 0, Size: 1}
+		return table
+	}
+
+	// DFS the tree, assigning bit 0 to the left child and 1 to the right
+	var assign func(node *huffmanNode, bits uint32, size int)
+
+	assign = func(node *huffmanNode, bits uint32, size int) {
+		if node.leaf {
+			table[node.char] = Code{Char: node.char, Prob: node.prob, Bits: bits, Size: size}
+			return
+		}
+
+		assign(node.left, bits<<1, size+1)
+		assign(node.right, bits<<1|1, size+1)
+	}
+
+	assign(h[0], 0, 0)
+
+	return table
+}
@@ -0,0 +1,96 @@
+package shannon
+
+import "testing"
+
+// freqFromString builds a probability map the same way
+// BuildTableFromString does, so BuildTable and BuildHuffmanTable can be
+// compared on an identical distribution.
+func freqFromString(s string) map[rune]float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	freq := make(map[rune]float64, len(counts))
+	for r, n := range counts {
+		freq[r] = float64(n) / float64(len(s))
+	}
+
+	return freq
+}
+
+// avgBitsPerSymbol returns the expected code length of table under freq,
+// normalized by the total of freq so the result is bits per rune even
+// when freq is scaled by byte length rather than rune count.
+func avgBitsPerSymbol(table Table, freq map[rune]float64) float64 {
+	var bits, total float64
+	for r, prob := range freq {
+		bits += prob * float64(table[r].Size)
+		total += prob
+	}
+	return bits / total
+}
+
+func TestHuffmanRoundTrip(t *testing.T) {
+	table := BuildHuffmanTable(freqFromString(corpus))
+
+	bitVec, size, err := table.Encode(corpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != corpus {
+		t.Fatalf("decoded text does not match corpus (len %d vs %d)", len(decoded), len(corpus))
+	}
+}
+
+func TestHuffmanSingleSymbol(t *testing.T) {
+	const s = "aaaa"
+
+	table := BuildHuffmanTable(freqFromString(s))
+
+	bitVec, size, err := table.Encode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size == 0 {
+		t.Fatal("single-symbol table encoded to zero bits")
+	}
+
+	decoded, err := table.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != s {
+		t.Fatalf("got %q, want %q", decoded, s)
+	}
+}
+
+func BenchmarkShannonFanoBitsPerSymbol(b *testing.B) {
+	freq := freqFromString(corpus)
+
+	var table Table
+	for i := 0; i < b.N; i++ {
+		table = BuildTable(freq)
+	}
+
+	b.ReportMetric(avgBitsPerSymbol(table, freq), "bits/symbol")
+}
+
+func BenchmarkHuffmanBitsPerSymbol(b *testing.B) {
+	freq := freqFromString(corpus)
+
+	var table Table
+	for i := 0; i < b.N; i++ {
+		table = BuildHuffmanTable(freq)
+	}
+
+	b.ReportMetric(avgBitsPerSymbol(table, freq), "bits/symbol")
+}
@@ -0,0 +1,145 @@
+package shannon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+// canonicalEntry pairs a symbol with the code length assigned to it; it
+// is the only information that needs to cross the wire, since Bits can
+// be reconstructed deterministically from the (length, symbol) order.
+type canonicalEntry struct {
+	char rune
+	size int
+}
+
+// MarshalBinary encodes t in canonical form: a count of symbols,
+// followed by one (rune, code length) pair per symbol, sorted by rune.
+// The Bits of each Code are not stored; a reader reconstructs them by
+// assigning codes in (length, symbol) order, starting at 0 and
+// left-shifting by the length delta whenever the length increases. This
+// is the same code assignment DEFLATE and JPEG use for Huffman tables.
+// Each symbol costs its rune as a signed varint (1 to 4 bytes) plus one
+// length byte; the runes are stored explicitly, not implied by position.
+func (t Table) MarshalBinary() ([]byte, error) {
+	entries := make([]canonicalEntry, 0, len(t))
+
+	for r, code := range t {
+		entries = append(entries, canonicalEntry{r, code.Size})
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].char < entries[b].char
+	})
+
+	var buf bytes.Buffer
+	hdr := make([]byte, binary.MaxVarintLen64)
+
+	n := binary.PutUvarint(hdr, uint64(len(entries)))
+	buf.Write(hdr[:n])
+
+	for _, e := range entries {
+		n = binary.PutVarint(hdr, int64(e.char))
+		buf.Write(hdr[:n])
+		buf.WriteByte(byte(e.size))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a Table previously written by MarshalBinary.
+func (t *Table) UnmarshalBinary(data []byte) error {
+	table, err := ReadTable(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	*t = table
+
+	return nil
+}
+
+// ReadTable reads a Table in the canonical wire format written by
+// MarshalBinary from r.
+func ReadTable(r io.Reader) (Table, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+
+	// there are only so many valid runes; reject anything larger outright
+	// rather than trusting the wire to size an allocation
+	if count > utf8.MaxRune {
+		return nil, errors.New("shannon: table symbol count out of range")
+	}
+
+	entries := make([]canonicalEntry, count)
+
+	for i := range entries {
+		rv, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = canonicalEntry{rune(rv), int(size)}
+	}
+
+	// canonical codes are assigned in (length, symbol) order
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].size != entries[b].size {
+			return entries[a].size < entries[b].size
+		}
+
+		return entries[a].char < entries[b].char
+	})
+
+	table := make(Table, len(entries))
+
+	var bits uint32
+	prevSize := 0
+
+	for i, e := range entries {
+		if i > 0 && e.size > prevSize {
+			bits <<= uint(e.size - prevSize)
+		}
+
+		table[e.char] = Code{Char: e.char, Bits: bits, Size: e.size}
+
+		bits++
+		prevSize = e.size
+	}
+
+	return table, nil
+}
+
+// Equal reports whether t and other contain exactly the same symbols
+// mapped to identical codes.
+func (t Table) Equal(other Table) bool {
+	if len(t) != len(other) {
+		return false
+	}
+
+	for r, code := range t {
+		oc, found := other[r]
+		if !found || oc != code {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package shannon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTableMarshalRoundTrip(t *testing.T) {
+	const s = "the quick brown fox jumps over the lazy dog"
+
+	table := BuildTableFromString(s)
+
+	data, err := table.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Table
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// canonical form preserves code lengths exactly, even though the
+	// assigned bit patterns may differ from the original tree
+	for r, code := range table {
+		gc, found := got[r]
+		if !found || gc.Size != code.Size {
+			t.Fatalf("rune %q: got %+v, want size %d", r, gc, code.Size)
+		}
+	}
+
+	// the canonical assignment is deterministic, so re-marshaling a
+	// decoded table reproduces the same bytes and an equal table
+	again, err := got.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, again) {
+		t.Fatal("canonical form is not stable across round trips")
+	}
+
+	var got2 Table
+	if err := got2.UnmarshalBinary(again); err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(got2) {
+		t.Fatal("Equal should hold for two decodes of the same canonical form")
+	}
+
+	// the round-tripped table must still encode/decode correctly
+	bitVec, size, err := got.Encode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := got.Decode(bitVec, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded != s {
+		t.Fatalf("got %q, want %q", decoded, s)
+	}
+}
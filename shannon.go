@@ -22,7 +22,6 @@
 package shannon
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"math"
@@ -40,10 +39,74 @@ type Code struct {
 // Table is a simple lookup-map for encoding and decoding.
 type Table map[rune]Code
 
+// shannonFanoBuild assigns Bits/Size to probs (pre-sorted descending by
+// probability) via Shannon-Fano partitioning. A single symbol is given
+// an explicit 1-bit code (0) rather than the 0-bit code partitioning
+// would otherwise leave it with, since a table still needs a valid,
+// decodable code even when every symbol in its source data is the same
+// byte or rune.
+func shannonFanoBuild(probs []float64, bits []uint32, sizes []int) {
+	if len(probs) == 1 {
+		sizes[0] = 1
+		return
+	}
+
+	shannonFanoAssign(probs, bits, sizes)
+}
+
+// shannonFanoAssign recursively partitions probs, pre-sorted by
+// descending probability, into two halves of roughly equal total
+// probability, incrementing sizes[i] and shifting in a 0 bit for the
+// left half or a 1 bit for the right half of bits[i], then recurses
+// into each half. probs, bits and sizes must be parallel slices. This
+// is shared by every Shannon-Fano table builder, regardless of the
+// symbol type the caller associates with each index.
+func shannonFanoAssign(probs []float64, bits []uint32, sizes []int) {
+	var p int
+
+	if len(probs) < 2 {
+		return
+	}
+
+	// sum the total probability for this slice
+	prob := 0.0
+	for _, pr := range probs {
+		prob += pr
+	}
+
+	// probability of the left half
+	left := probs[0]
+	best := 1.0
+
+	// find the optimal pivot
+	for p = 1; p < len(probs)-1; p++ {
+		if diff := math.Abs((prob - left) - left); diff < best {
+			best = diff
+		} else {
+			break
+		}
+
+		// tally the probability on the left
+		left += probs[p]
+	}
+
+	// update the left half with 0's and right half with 1's
+	for i := range probs {
+		bits[i] <<= 1
+		sizes[i]++
+
+		if i >= p {
+			bits[i] |= 1
+		}
+	}
+
+	// subdivide each branch
+	shannonFanoAssign(probs[:p], bits[:p], sizes[:p])
+	shannonFanoAssign(probs[p:], bits[p:], sizes[p:])
+}
+
 // BuildTable returns Shannon-Fano table for encoding an decoding.
 func BuildTable(freq map[rune]float64) (table Table) {
-	var divide func([]Code)
-
 	// initialize an empty list capable of holding all codes
 	codes := make([]Code, 0, len(freq))
 
@@ -60,59 +123,23 @@ func BuildTable(freq map[rune]float64) (table Table) {
 		return codes[a].Prob > codes[b].Prob
 	})
 
-	// recursively divide the codes, building the table
-	divide = func(codes []Code) {
-		var p int
-
-		if len(codes) < 2 {
-			return
-		}
-
-		// sum the total probability for this slice
-		prob := 0.0
-		for _, code := range codes {
-			prob += code.Prob
-		}
-
-		// probability of the left half
-		left := codes[0].Prob
-		best := 1.0
-
-		// find the optimal pivot
-		for p = 1; p < len(codes)-1; p++ {
-			if diff := math.Abs((prob - left) - left); diff < best {
-				best = diff
-			} else {
-				break
-			}
-
-			// tally the probability on the left
-			left += codes[p].Prob
-		}
-
-		// update the left half with 0's and right half with 1's
-		for i := 0; i < len(codes); i++ {
-			codes[i].Bits <<= 1
-			codes[i].Size++
-
-			if i >= p {
-				codes[i].Bits |= 1
-			}
-		}
-
-		// subdivide each branch
-		divide(codes[:p])
-		divide(codes[p:])
+	// assign bits/sizes via the shared Shannon-Fano partitioning
+	probs := make([]float64, len(codes))
+	for i, code := range codes {
+		probs[i] = code.Prob
 	}
 
-	// perform the subdivision
-	divide(codes)
+	bits := make([]uint32, len(codes))
+	sizes := make([]int, len(codes))
+
+	shannonFanoBuild(probs, bits, sizes)
 
 	// create the resulting table
-	table = make(Table)
+	table = make(Table, len(codes))
 
 	// construct the table from all the built codes
-	for _, code := range codes {
+	for i, code := range codes {
+		code.Bits, code.Size = bits[i], sizes[i]
 		table[code.Char] = code
 	}
 
@@ -191,56 +218,3 @@ func (t Table) Encode(s string) ([]uint32, int, error) {
 
 	return bitVec, size, nil
 }
-
-// Decode a bit vector into a string using a Shannon-Fano table.
-func (t Table) Decode(bitVec []uint32, size int) (string, error) {
-	var b bytes.Buffer
-	var v uint32
-
-	// ensure there are enough bits to decode
-	if len(bitVec) <= size/32 {
-		return "", errors.New("invalid bit vector")
-	}
-
-	// current bits/size being tested
-	bits, n := uint32(0), 0
-
-	// pop the first set of bits in the vector
-	v, bitVec = bitVec[0], bitVec[1:]
-
-	// pop bits until vector is completely consumed
-	for i := 0; i < size; {
-		bits, v = bits<<1|(v>>31), v<<1
-
-		// tally bits, test for failure
-		if n++; n > 32 {
-			return "", errors.New("invalid bit vector or missing shannon code")
-		}
-
-		// pop the next set of bits from the bit vector
-		if i++; i&0x1F == 0 {
-			v, bitVec = bitVec[0], bitVec[1:]
-		}
-
-		// find a matching code
-		for r, code := range t {
-			if code.Size != n || code.Bits != bits {
-				continue
-			}
-
-			// found matching code
-			b.WriteRune(r)
-
-			// reset the bits/size being tested
-			bits, n = 0, 0
-			break
-		}
-	}
-
-	// ensure all bits were used
-	if n != 0 {
-		return b.String(), errors.New("encoded bits remaining")
-	}
-
-	return b.String(), nil
-}
@@ -0,0 +1,237 @@
+package shannon
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Writer encodes runes written to it using a Shannon-Fano table, packing
+// the resulting bits byte-aligned in memory. It implements
+// io.WriteCloser; nothing reaches the underlying io.Writer until Close
+// is called, since the frame header must record the total encoded
+// bit-length before the packed bytes that follow it.
+type Writer struct {
+	w       io.Writer
+	t       Table
+	pending []byte // incomplete trailing UTF-8 bytes from a previous Write
+	buf     []byte // encoded bytes accumulated so far
+	cur     byte   // bits not yet packed into buf
+	nbits   uint
+	size    int // total number of encoded bits
+	closed  bool
+}
+
+// NewWriter returns a Writer that encodes runes with t and, once Close is
+// called, writes a varint-prefixed frame (the total encoded bit-length
+// followed by the packed bytes) to w.
+func NewWriter(w io.Writer, t Table) *Writer {
+	return &Writer{w: w, t: t}
+}
+
+// Write encodes each complete rune in p. Incomplete trailing UTF-8
+// sequences are buffered and completed by a subsequent Write or reported
+// as an error from Close.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("shannon: write to closed Writer")
+	}
+
+	data := p
+	if len(w.pending) > 0 {
+		data = append(w.pending, p...)
+		w.pending = nil
+	}
+
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			if !utf8.FullRune(data) {
+				w.pending = append([]byte(nil), data...)
+				break
+			}
+
+			return len(p), errors.New("shannon: invalid UTF-8 sequence")
+		}
+
+		code, found := w.t[r]
+		if !found {
+			return len(p), fmt.Errorf("rune '%c' not found in shannon-fano table", r)
+		}
+
+		w.writeCode(code)
+		data = data[size:]
+	}
+
+	return len(p), nil
+}
+
+// writeCode packs the low code.Size bits of code.Bits, most significant
+// first, into the byte buffer, flushing a byte to buf each time 8 bits have accumulated.
+func (w *Writer) writeCode(code Code) {
+	for i := code.Size - 1; i >= 0; i-- {
+		w.cur = w.cur<<1 | byte((code.Bits>>uint(i))&1)
+
+		if w.nbits++; w.nbits == 8 {
+			w.buf = append(w.buf, w.cur)
+			w.cur, w.nbits = 0, 0
+		}
+	}
+
+	w.size += code.Size
+}
+
+// Close flushes the trailing partial byte (if any), then writes the
+// frame header (a uvarint encoding the total bit-length) followed by the
+// packed, byte-aligned bits to the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if len(w.pending) > 0 {
+		return errors.New("shannon: incomplete UTF-8 sequence at close")
+	}
+
+	if w.nbits > 0 {
+		w.buf = append(w.buf, w.cur<<(8-w.nbits))
+	}
+
+	hdr := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(hdr, uint64(w.size))
+
+	if _, err := w.w.Write(hdr[:n]); err != nil {
+		return err
+	}
+
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Reader decodes a stream produced by a Writer, maintaining a rolling
+// bit buffer and stopping once the bit count recorded in the frame
+// header has been consumed. It implements io.ReadCloser.
+type Reader struct {
+	br        io.ByteReader
+	dec       *Decoder
+	remaining int // bits left to decode
+	cur       byte
+	nbits     uint
+	out       []byte // decoded bytes not yet returned to the caller
+	err       error
+	started   bool
+}
+
+// NewReader returns a Reader that decodes the frame written by a Writer
+// using t.
+func NewReader(r io.Reader, t Table) *Reader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &Reader{br: br, dec: t.NewDecoder()}
+}
+
+// Read implements io.Reader. Each call decodes at most one rune and
+// returns as many of its pending UTF-8 bytes as fit in p.
+func (r *Reader) Read(p []byte) (int, error) {
+	if !r.started {
+		r.started = true
+
+		size, err := binary.ReadUvarint(r.br)
+		if err != nil {
+			r.err = err
+		} else {
+			r.remaining = int(size)
+		}
+	}
+
+	for len(r.out) == 0 && r.err == nil {
+		r.fill()
+	}
+
+	if len(r.out) == 0 {
+		return 0, r.err
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+
+	return n, nil
+}
+
+// fill decodes the next rune (if any remain) and appends its UTF-8
+// encoding to out.
+func (r *Reader) fill() {
+	if r.remaining == 0 {
+		r.err = io.EOF
+		return
+	}
+
+	ru, err := r.readRune()
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], ru)
+	r.out = append(r.out, buf[:n]...)
+}
+
+// readRune walks the decoder's prefix-trie one bit at a time until it
+// reaches a leaf, never consuming more bits than r.remaining records.
+func (r *Reader) readRune() (rune, error) {
+	node, n := r.dec.root, 0
+
+	for {
+		if n >= r.remaining {
+			return 0, errors.New("shannon: encoded bits remaining")
+		}
+
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+
+		if node = node.children[bit]; node == nil {
+			return 0, errors.New("shannon: invalid bit stream or missing shannon code")
+		}
+
+		if n++; node.isLeaf {
+			r.remaining -= n
+			return node.char, nil
+		}
+
+		if n > 32 {
+			return 0, errors.New("shannon: invalid bit stream or missing shannon code")
+		}
+	}
+}
+
+// readBit pops the next bit from the rolling bit buffer, refilling it
+// from the underlying reader a byte at a time.
+func (r *Reader) readBit() (byte, error) {
+	if r.nbits == 0 {
+		b, err := r.br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		r.cur, r.nbits = b, 8
+	}
+
+	r.nbits--
+
+	return (r.cur >> r.nbits) & 1, nil
+}
+
+// Close is a no-op; it exists to satisfy io.ReadCloser.
+func (r *Reader) Close() error {
+	return nil
+}
@@ -0,0 +1,77 @@
+package shannon
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	const s = "the quick brown fox jumps over the lazy dog, Σὲ γνωρίζω ἀπὸ τὴν κόψη, 北京市"
+
+	table := BuildTableFromString(s)
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf, table)
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(&buf, table)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != s {
+		t.Fatalf("got %q, want %q", got, s)
+	}
+}
+
+func TestStreamRoundTripSmallReads(t *testing.T) {
+	const s = "the quick brown fox jumps over the lazy dog, Σὲ γνωρίζω, 北京市"
+
+	table := BuildTableFromString(s)
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf, table)
+
+	// write one byte at a time to exercise rune boundaries split across
+	// separate Write calls
+	for i := 0; i < len(s); i++ {
+		if _, err := w.Write([]byte{s[i]}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(&buf, table)
+	defer r.Close()
+
+	var out bytes.Buffer
+	tmp := make([]byte, 3)
+
+	for {
+		n, err := r.Read(tmp)
+		out.Write(tmp[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if out.String() != s {
+		t.Fatalf("got %q, want %q", out.String(), s)
+	}
+}